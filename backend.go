@@ -0,0 +1,41 @@
+package ring
+
+import "fmt"
+
+// validateSize checks the preconditions newBacking relies on: the page size
+// must be a power of 2 (true everywhere, but double checked since the rest
+// of the math depends on it) and size a multiple of it, with 2*size not
+// overflowing uintptr.
+func validateSize(size uintptr) error {
+	if pageSize&(pageSize-1) != 0 {
+		return fmt.Errorf("page size must be a power of 2")
+	}
+
+	if size%pageSize != 0 {
+		return fmt.Errorf("size must be a multiple of the page size")
+	}
+
+	if totalMappingSize := size * 2; totalMappingSize < size {
+		return fmt.Errorf("size overflow when creating MMU-ring")
+	}
+
+	return nil
+}
+
+// Every platform implements newBacking(size uintptr) (buffer unsafe.Pointer, fd int, unmap func() error, err error)
+// in its own build-tagged file (ring_linux.go, ring_shm.go, ring_windows.go).
+//
+// newBacking reserves 2*size bytes of contiguous virtual memory and maps a
+// freshly allocated size-byte physical region into both halves, so that the
+// returned buffer can be read or written across the size boundary as if it
+// were a single, linear, infinitely repeating copy of the same memory.
+//
+// fd is the raw file descriptor backing the mapping so it can be shared with
+// another process (see (*Ring).FD and AttachFD), or -1 on platforms where
+// that concept doesn't apply (Windows).
+//
+// unmap releases both the mapping and any backing storage (file descriptor,
+// handle, ...) used to create it. Platforms implement this with whatever
+// facility gives them two independent mappings onto the same pages: memfd on
+// Linux, POSIX shared memory on BSD-family kernels, and the placeholder VA
+// APIs on Windows.
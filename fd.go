@@ -0,0 +1,108 @@
+//go:build unix
+
+package ring
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// FD returns the file descriptor backing the ring's memory. It stays open
+// and owned by the Ring (closed by Close) for as long as the Ring is alive,
+// which lets it be shared with another process, e.g. via SendOver.
+func (r *Ring) FD() int {
+	return r.fd
+}
+
+// AttachFD builds a Ring on top of an existing fd sized to hold size bytes,
+// double-mapping it the same way New does so a producer in one process and a
+// consumer in another can share the same physical pages. AttachFD dup(2)s fd
+// internally, so the returned Ring owns an independent descriptor and
+// closing it has no effect on fd; the caller keeps ownership of fd and is
+// still responsible for closing it.
+func AttachFD(fd int, size uintptr) (*Ring, error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
+	}
+
+	dupFd, err := unix.Dup(fd)
+	if err != nil {
+		return nil, fmt.Errorf("dup: %w", err)
+	}
+
+	buffer, unmap, err := attachBacking(dupFd, size)
+	if err != nil {
+		unix.Close(dupFd)
+		return nil, err
+	}
+
+	return &Ring{size: size, buffer: (*byte)(buffer), unmap: unmap, fd: dupFd}, nil
+}
+
+// SendOver sends the ring's backing fd and size to the other end of conn as
+// an SCM_RIGHTS ancillary message, so the receiver can reconstruct the ring
+// with ReceiveOver or AttachFD. The ring keeps using its own fd and mapping.
+func (r *Ring) SendOver(conn *net.UnixConn) error {
+	var sizeMsg [8]byte
+	binary.LittleEndian.PutUint64(sizeMsg[:], uint64(r.size))
+
+	_, _, err := conn.WriteMsgUnix(sizeMsg[:], unix.UnixRights(r.fd), nil)
+	if err != nil {
+		return fmt.Errorf("send ring fd: %w", err)
+	}
+	return nil
+}
+
+// ReceiveOver receives a fd and size sent by SendOver and attaches a Ring to it.
+func ReceiveOver(conn *net.UnixConn) (*Ring, error) {
+	var sizeMsg [8]byte
+	oob := make([]byte, unix.CmsgSpace(4))
+
+	n, oobn, _, _, err := conn.ReadMsgUnix(sizeMsg[:], oob)
+	if err != nil {
+		return nil, fmt.Errorf("receive ring fd: %w", err)
+	}
+	if n != len(sizeMsg) {
+		return nil, fmt.Errorf("receive ring fd: got %d size bytes, want %d", n, len(sizeMsg))
+	}
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, fmt.Errorf("parse control message: %w", err)
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("receive ring fd: got %d control messages, want 1", len(msgs))
+	}
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	if err != nil {
+		return nil, fmt.Errorf("parse unix rights: %w", err)
+	}
+	if len(fds) != 1 {
+		return nil, fmt.Errorf("receive ring fd: got %d fds, want 1", len(fds))
+	}
+	defer unix.Close(fds[0]) // AttachFD dups it, so this one is ours to close
+
+	return AttachFD(fds[0], uintptr(binary.LittleEndian.Uint64(sizeMsg[:])))
+}
+
+// fdOf reports the raw file descriptor backing v, if v supports exposing
+// one (e.g. *net.TCPConn, *net.UnixConn, *os.File).
+func fdOf(v any) (int, bool) {
+	sc, ok := v.(syscall.Conn)
+	if !ok {
+		return 0, false
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+	var fd int
+	if ctrlErr := raw.Control(func(f uintptr) { fd = int(f) }); ctrlErr != nil {
+		return 0, false
+	}
+	return fd, true
+}
@@ -0,0 +1,134 @@
+//go:build unix
+
+package ring
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestRing_AttachFD(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Close(); err != nil {
+			t.Errorf("r.Close failed: %v", err)
+		}
+	})
+
+	testData := []byte("shared across an fd")
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// AttachFD dups r.FD(), so r and attached own independent descriptors:
+	// both can be kept alive and closed without one tearing down the other's.
+	attached, err := AttachFD(r.FD(), r.Size())
+	if err != nil {
+		t.Fatalf("AttachFD failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := attached.Close(); err != nil {
+			t.Errorf("attached.Close failed: %v", err)
+		}
+	})
+
+	// AttachFD only reconstructs the shared mapping; head/tail bookkeeping is
+	// local to each Ring, so the caller must still communicate how much data
+	// is available (e.g. as part of the message that carried the fd).
+	if err := attached.Advance(uintptr(len(testData))); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if !bytes.Equal(attached.Content(), testData) {
+		t.Errorf("attached Content() = %q, want %q", attached.Content(), testData)
+	}
+}
+
+func TestRing_SendReceiveOver(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	testData := []byte("sent over SCM_RIGHTS")
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	left, right, err := unixSocketpair()
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	defer left.Close()
+	defer right.Close()
+
+	if err := r.SendOver(left); err != nil {
+		t.Fatalf("SendOver failed: %v", err)
+	}
+
+	received, err := ReceiveOver(right)
+	if err != nil {
+		t.Fatalf("ReceiveOver failed: %v", err)
+	}
+	defer received.Close()
+
+	if received.Size() != r.Size() {
+		t.Errorf("received Size() = %d, want %d", received.Size(), r.Size())
+	}
+	if err := received.Advance(uintptr(len(testData))); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if !bytes.Equal(received.Content(), testData) {
+		t.Errorf("received Content() = %q, want %q", received.Content(), testData)
+	}
+}
+
+// unixSocketpair returns a connected pair of *net.UnixConn usable to test
+// SCM_RIGHTS transfer without touching the filesystem.
+func unixSocketpair() (*net.UnixConn, *net.UnixConn, error) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leftFile, err := fileFromFD(fds[0], "left")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer leftFile.Close()
+	rightFile, err := fileFromFD(fds[1], "right")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rightFile.Close()
+
+	leftConn, err := net.FileConn(leftFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	rightConn, err := net.FileConn(rightFile)
+	if err != nil {
+		leftConn.Close()
+		return nil, nil, err
+	}
+
+	return leftConn.(*net.UnixConn), rightConn.(*net.UnixConn), nil
+}
+
+func fileFromFD(fd int, name string) (*os.File, error) {
+	if err := unix.SetNonblock(fd, true); err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), name), nil
+}
@@ -0,0 +1,40 @@
+//go:build linux
+
+package ring
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// WritevTo writes the ring's buffered content to fd with a single writev(2)
+// call, consuming what was written. It's the vectored counterpart to
+// WriteTo, for callers that already work in terms of raw fds and want to
+// avoid going through the io.Writer/splice machinery.
+func (r *Ring) WritevTo(fd int) (int, error) {
+	iovecs := r.Iovecs()
+	if len(iovecs) == 0 {
+		return 0, nil
+	}
+
+	n, err := unix.Writev(fd, iovecs)
+	if cerr := r.Consume(uintptr(n)); cerr != nil && err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// ReadvFrom reads from fd into the ring's unused space with a single
+// readv(2) call, advancing the ring by what was read. It's the vectored
+// counterpart to ReadFrom.
+func (r *Ring) ReadvFrom(fd int) (int, error) {
+	unused := r.Unused()
+	if len(unused) == 0 {
+		return 0, nil
+	}
+
+	n, err := unix.Readv(fd, [][]byte{unused})
+	if cerr := r.Advance(uintptr(n)); cerr != nil && err == nil {
+		err = cerr
+	}
+	return n, err
+}
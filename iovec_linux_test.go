@@ -0,0 +1,95 @@
+//go:build linux
+
+package ring
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRing_WritevToReadvFrom(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	left, right, err := unixSocketpair()
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	defer left.Close()
+	defer right.Close()
+
+	testData := []byte("routed through WritevTo/ReadvFrom")
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	leftFD, ok := fdOf(left)
+	if !ok {
+		t.Fatalf("fdOf(left) failed")
+	}
+	moved, err := r.WritevTo(leftFD)
+	if err != nil {
+		t.Fatalf("WritevTo failed: %v", err)
+	}
+	if moved != len(testData) {
+		t.Fatalf("WritevTo moved %d bytes, want %d", moved, len(testData))
+	}
+
+	got := make([]byte, len(testData))
+	if _, err := readFull(right, got); err != nil {
+		t.Fatalf("read back failed: %v", err)
+	}
+	if !bytes.Equal(got, testData) {
+		t.Errorf("WritevTo data = %q, want %q", got, testData)
+	}
+
+	rightFD, ok := fdOf(right)
+	if !ok {
+		t.Fatalf("fdOf(right) failed")
+	}
+	if _, err := left.Write(testData); err != nil {
+		t.Fatalf("Write to socket failed: %v", err)
+	}
+	moved, err = r.ReadvFrom(rightFD)
+	if err != nil {
+		t.Fatalf("ReadvFrom failed: %v", err)
+	}
+	if moved != len(testData) {
+		t.Fatalf("ReadvFrom moved %d bytes, want %d", moved, len(testData))
+	}
+	if !bytes.Equal(r.Content(), testData) {
+		t.Errorf("Content() after ReadvFrom = %q, want %q", r.Content(), testData)
+	}
+}
+
+func TestRing_Iovecs(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	if iovecs := r.Iovecs(); len(iovecs) != 0 {
+		t.Fatalf("Iovecs() on empty ring = %d slices, want 0", len(iovecs))
+	}
+
+	testData := []byte("iovecs")
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	iovecs := r.Iovecs()
+	if len(iovecs) != 1 {
+		t.Fatalf("Iovecs() = %d slices, want 1", len(iovecs))
+	}
+	if !bytes.Equal(iovecs[0], testData) {
+		t.Errorf("Iovecs()[0] = %q, want %q", iovecs[0], testData)
+	}
+}
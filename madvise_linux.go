@@ -0,0 +1,35 @@
+//go:build linux
+
+package ring
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Release consumes the next n bytes like Consume, and additionally tells the
+// kernel it can reclaim the physical pages that backed them (MADV_REMOVE),
+// following the commit/decommit model gvisor's pgalloc uses for sparse
+// memory files: the double virtual mapping stays intact, and the released
+// pages are transparently re-faulted (zeroed) the next time something
+// writes to that region. This lets a ring be sized in gigabytes while only
+// costing physical memory proportional to what's actually buffered.
+//
+// n, and the offset it's released from, should be page aligned: MADV_REMOVE
+// punches holes at page granularity, so Release returns an error otherwise.
+func (r *Ring) Release(n uintptr) error {
+	if n == 0 {
+		return nil
+	}
+	if r.tail%pageSize != 0 || n%pageSize != 0 {
+		return fmt.Errorf("release: offset %d and length %d must be page aligned", r.tail, n)
+	}
+
+	addr := unsafe.Add(unsafe.Pointer(r.buffer), r.tail)
+	if err := r.Consume(n); err != nil {
+		return err
+	}
+	return unix.Madvise(unsafe.Slice((*byte)(addr), int(n)), unix.MADV_REMOVE)
+}
@@ -0,0 +1,47 @@
+//go:build unix
+
+package ring
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestRing_AdvisePrefault(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	testData := bytes.Repeat([]byte{0x42}, 100)
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if err := r.Prefault(); err != nil {
+		t.Fatalf("Prefault failed: %v", err)
+	}
+	if err := r.Advise(unix.MADV_WILLNEED); err != nil {
+		t.Fatalf("Advise failed: %v", err)
+	}
+	if !bytes.Equal(r.Content(), testData) {
+		t.Errorf("Content() after Advise/Prefault = %q, want %q", r.Content(), testData)
+	}
+}
+
+func TestRing_PrefaultEmpty(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	if err := r.Prefault(); err != nil {
+		t.Fatalf("Prefault on empty ring failed: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package ring
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Advise calls madvise(2) with advice over the ring's entire backing
+// mapping (both virtual copies), letting the kernel tune its paging
+// behavior for access patterns the ring's own bookkeeping can't express,
+// e.g. MADV_DONTNEED or a platform-specific hint.
+func (r *Ring) Advise(advice int) error {
+	return unix.Madvise(unsafe.Slice((*byte)(unsafe.Pointer(r.buffer)), int(r.size*2)), advice)
+}
+
+// Prefault hints the kernel (MADV_WILLNEED) to fault in the pages backing
+// the ring's currently buffered content ahead of time, for callers that
+// know they're about to Read or Consume it.
+func (r *Ring) Prefault() error {
+	data := r.Content()
+	if len(data) == 0 {
+		return nil
+	}
+	return unix.Madvise(data, unix.MADV_WILLNEED)
+}
@@ -0,0 +1,115 @@
+//go:build unix
+
+package ring
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	persistentMagic   = 0x6d6d7572 // "mmur"
+	persistentVersion = 1
+)
+
+// NewFromFile opens or creates a Ring backed by the regular file at path,
+// instead of an anonymous memfd/shm object, so the buffered data and the
+// producer/consumer position survive the process restarting. A page ahead
+// of the ring's own size bytes stores a persistentHeader identifying the
+// file and mirroring tail/contentLen; (*Ring).Advance, Consume and Sync keep
+// it up to date so a later call to NewFromFile on the same path resumes
+// exactly where the previous process left off.
+func NewFromFile(path string, size uintptr) (*Ring, error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
+	}
+
+	file, err := unix.Open(path, unix.O_CREAT|unix.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	closeFile := true
+	defer func() {
+		if closeFile {
+			unix.Close(file)
+		}
+	}()
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(file, &stat); err != nil {
+		return nil, fmt.Errorf("fstat %s: %w", path, err)
+	}
+
+	wantSize := int64(pageSize) + int64(size)
+	fresh := stat.Size == 0
+	if !fresh && stat.Size != wantSize {
+		return nil, fmt.Errorf("open %s: existing file is %d bytes, want %d for a %d byte ring", path, stat.Size, wantSize, size)
+	}
+	if fresh {
+		if err := unix.Ftruncate(file, wantSize); err != nil {
+			return nil, fmt.Errorf("ftruncate %s: %w", path, err)
+		}
+	}
+
+	headerPage, err := unix.MmapPtr(file, 0, nil, pageSize, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("mmap header: %w", err)
+	}
+	closeHeader := true
+	defer func() {
+		if closeHeader {
+			unix.MunmapPtr(headerPage, pageSize)
+		}
+	}()
+	header := (*persistentHeader)(headerPage)
+
+	if fresh {
+		*header = persistentHeader{magic: persistentMagic, version: persistentVersion}
+	} else {
+		if header.magic != persistentMagic {
+			return nil, fmt.Errorf("open %s: bad magic %#x, not an mmu-ring file", path, header.magic)
+		}
+		if header.version != persistentVersion {
+			return nil, fmt.Errorf("open %s: unsupported header version %d, want %d", path, header.version, persistentVersion)
+		}
+	}
+
+	data, unmapData, err := attachBackingAt(file, int64(pageSize), size)
+	if err != nil {
+		return nil, err
+	}
+
+	closeFile = false
+	closeHeader = false
+	unmap := func() error {
+		err := unix.MunmapPtr(headerPage, pageSize)
+		if derr := unmapData(); err == nil {
+			err = derr
+		}
+		return err
+	}
+
+	return &Ring{
+		size:       size,
+		tail:       uintptr(header.tail),
+		contentLen: uintptr(header.contentLen),
+		buffer:     (*byte)(data),
+		unmap:      unmap,
+		fd:         file,
+		header:     header,
+		headerPage: headerPage,
+	}, nil
+}
+
+// Sync flushes the header page (tail and contentLen) to disk with msync(2).
+// The ring's data pages need no equivalent call: they are a shared mapping
+// of the same file, so writes to them are already visible to the next
+// NewFromFile. It is a no-op for rings not created by NewFromFile.
+func (r *Ring) Sync() error {
+	if r.headerPage == nil {
+		return nil
+	}
+	return unix.Msync(unsafe.Slice((*byte)(r.headerPage), int(pageSize)), unix.MS_SYNC)
+}
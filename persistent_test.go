@@ -0,0 +1,76 @@
+//go:build unix
+
+package ring
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRing_NewFromFile_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+
+	r, err := NewFromFile(path, 4096)
+	if err != nil {
+		t.Fatalf("NewFromFile failed: %v", err)
+	}
+
+	testData := []byte("persisted across restart")
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := r.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened, err := NewFromFile(path, 4096)
+	if err != nil {
+		t.Fatalf("re-open NewFromFile failed: %v", err)
+	}
+	defer reopened.Close()
+
+	if !bytes.Equal(reopened.Content(), testData) {
+		t.Errorf("Content() after reopen = %q, want %q", reopened.Content(), testData)
+	}
+
+	if err := reopened.Consume(uintptr(len(testData))); err != nil {
+		t.Fatalf("Consume failed: %v", err)
+	}
+	if len(reopened.Content()) != 0 {
+		t.Errorf("Content() after Consume = %q, want empty", reopened.Content())
+	}
+}
+
+func TestRing_NewFromFile_RejectsSizeMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+
+	r, err := NewFromFile(path, 4096)
+	if err != nil {
+		t.Fatalf("NewFromFile failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := NewFromFile(path, 8192); err == nil {
+		t.Fatalf("NewFromFile with mismatched size succeeded, want error")
+	}
+}
+
+func TestRing_NewFromFile_RejectsBadMagic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	if err := os.WriteFile(path, make([]byte, int(pageSize)+4096), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := NewFromFile(path, 4096); err == nil {
+		t.Fatalf("NewFromFile over a non-mmu-ring file succeeded, want error")
+	}
+}
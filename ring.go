@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os"
 	"unsafe"
-
-	"golang.org/x/sys/unix"
 )
 
 var pageSize = uintptr(os.Getpagesize())
@@ -13,6 +11,23 @@ var pageSize = uintptr(os.Getpagesize())
 type Ring struct {
 	size, contentLen, tail uintptr
 	buffer                 *byte // manually managed memory
+	unmap                  func() error
+	fd                     int // backing fd, see (*Ring).FD; -1 where not applicable
+
+	// header and headerPage are non-nil only for rings created by
+	// NewFromFile: header mirrors tail/contentLen into the mmapped header
+	// page so a future process can resume draining, and headerPage is the
+	// raw mapping Sync flushes.
+	header     *persistentHeader
+	headerPage unsafe.Pointer
+}
+
+// persistentHeader is the layout of the header page NewFromFile stores ahead
+// of a persistent ring's data region. Field widths are fixed regardless of
+// platform so the file format doesn't depend on uintptr size.
+type persistentHeader struct {
+	magic, version   uint32
+	tail, contentLen uint64
 }
 
 func New(size uintptr) (*Ring, error) {
@@ -30,50 +45,16 @@ func (r *Ring) Init(size uintptr) (err error) {
 		return fmt.Errorf("ring already initialized")
 	}
 
-	if pageSize&(pageSize-1) != 0 {
-		return fmt.Errorf("page size must be a power of 2")
+	if err := validateSize(size); err != nil {
+		return err
 	}
 
-	if size%pageSize != 0 {
-		return fmt.Errorf("size must be a multiple of the page size")
-	}
-
-	file, err := unix.MemfdCreate("github.com/Jorropo/mmu-ring", unix.MFD_CLOEXEC) // name does nothing and just used for debug
+	buffer, fd, unmap, err := newBacking(size)
 	if err != nil {
-		return fmt.Errorf("memfd_create: %w", err)
+		return err
 	}
-	defer unix.Close(file) // linux will cleanup the files once the mappings are unmapped
 
-	if err := unix.Ftruncate(file, int64(size)); err != nil {
-		return fmt.Errorf("ftruncate: %w", err)
-	}
-	totalMappingSize := size * 2
-	if totalMappingSize < size {
-		return fmt.Errorf("size overflow when creating MMU-ring")
-	}
-
-	// temporary mapping to allocate twice the ring of virtual memory
-	orig, err := unix.MmapPtr(-1, 0, nil, totalMappingSize, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANONYMOUS|unix.MAP_NORESERVE|unix.MAP_PRIVATE)
-	if err != nil {
-		return fmt.Errorf("virtual mmap: %w", err)
-	}
-	defer func() {
-		if err != nil {
-			unix.MunmapPtr(orig, totalMappingSize)
-		}
-	}()
-
-	// replace the virtual reservation with the physical memory tail to head
-	_, err = unix.MmapPtr(file, 0, orig, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_FIXED)
-	if err != nil {
-		return fmt.Errorf("first physical mmap: %w", err)
-	}
-	_, err = unix.MmapPtr(file, 0, unsafe.Add(orig, size), size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_FIXED)
-	if err != nil {
-		return fmt.Errorf("second physical mmap: %w", err)
-	}
-
-	*r = Ring{size: size, buffer: (*byte)(orig)}
+	*r = Ring{size: size, buffer: (*byte)(buffer), unmap: unmap, fd: fd}
 	return nil
 }
 
@@ -81,8 +62,12 @@ func (r *Ring) Close() error {
 	if r.buffer == nil {
 		return nil
 	}
-	err := unix.MunmapPtr(unsafe.Pointer(r.buffer), r.size*2)
+	err := r.unmap()
 	r.buffer = nil
+	r.unmap = nil
+	r.fd = -1
+	r.header = nil
+	r.headerPage = nil
 	return err
 }
 
@@ -102,6 +87,9 @@ func (r *Ring) Advance(n uintptr) error {
 	}
 
 	r.contentLen += n
+	if r.header != nil {
+		r.header.contentLen = uint64(r.contentLen)
+	}
 	return nil
 }
 
@@ -126,6 +114,19 @@ func (r *Ring) Content() []byte {
 	return unsafe.Slice((*byte)(unsafe.Add(unsafe.Pointer(r.buffer), r.tail)), int(r.contentLen))
 }
 
+// Iovecs returns the ring's buffered content as the slices readv(2)/writev(2)
+// (and anything else built around scatter/gather I/O) expect. Because the
+// double mapping already makes Content a single contiguous slice, today this
+// always returns zero or one slice; the plural name and slice-of-slices
+// return type leave room for a future backend that isn't magic-mapped and
+// would need to report a second slice for the wrapped tail.
+func (r *Ring) Iovecs() [][]byte {
+	if r.contentLen == 0 {
+		return nil
+	}
+	return [][]byte{r.Content()}
+}
+
 func (r *Ring) Consume(n uintptr) error {
 	if n > r.contentLen {
 		return fmt.Errorf("not enough data in ring")
@@ -133,6 +134,10 @@ func (r *Ring) Consume(n uintptr) error {
 
 	r.tail = (r.tail + n) % r.size
 	r.contentLen -= n
+	if r.header != nil {
+		r.header.tail = uint64(r.tail)
+		r.header.contentLen = uint64(r.contentLen)
+	}
 	return nil
 }
 
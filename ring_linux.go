@@ -0,0 +1,42 @@
+//go:build linux
+
+package ring
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// anonMmapFlags is the Linux anonymous reservation flags used by
+// attachBackingAt (ring_unix.go). MAP_NORESERVE tells the kernel not to
+// reserve swap/commit accounting for the temporary double-sized virtual
+// range, since it's immediately replaced by MAP_FIXED mappings of the real
+// backing fd and never itself holds any data.
+const anonMmapFlags = unix.MAP_ANONYMOUS | unix.MAP_NORESERVE | unix.MAP_PRIVATE
+
+// newBacking implements the double mapping using memfd_create, Linux's
+// native anonymous-file-with-an-fd facility. The fd is kept open (instead of
+// closed once mapped) so it can later be exported through (*Ring).FD.
+func newBacking(size uintptr) (buffer unsafe.Pointer, fd int, unmap func() error, err error) {
+	file, err := unix.MemfdCreate("github.com/Jorropo/mmu-ring", unix.MFD_CLOEXEC) // name does nothing and just used for debug
+	if err != nil {
+		return nil, -1, nil, fmt.Errorf("memfd_create: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			unix.Close(file)
+		}
+	}()
+
+	if err := unix.Ftruncate(file, int64(size)); err != nil {
+		return nil, -1, nil, fmt.Errorf("ftruncate: %w", err)
+	}
+
+	buffer, unmap, err = attachBacking(file, size)
+	if err != nil {
+		return nil, -1, nil, err
+	}
+	return buffer, file, unmap, nil
+}
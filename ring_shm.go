@@ -0,0 +1,78 @@
+//go:build darwin || freebsd
+
+package ring
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+var shmCounter atomic.Uint64
+
+// anonMmapFlags is the Darwin/FreeBSD anonymous reservation flags used by
+// attachBackingAt (ring_unix.go). Unlike Linux, MAP_NORESERVE isn't
+// available here, so the temporary double-sized virtual range is reserved
+// with no extra flags beyond the anonymous private mapping itself.
+const anonMmapFlags = unix.MAP_ANONYMOUS | unix.MAP_PRIVATE
+
+// newBacking implements the double mapping with POSIX shared memory, since
+// neither Darwin nor FreeBSD have memfd_create. The shm_open object's name
+// is never used again once it is opened, so it is unlinked when newBacking
+// returns, leaving an anonymous, refcounted piece of memory much like a
+// Linux memfd. The fd is kept open
+// (instead of closed once mapped) so it can later be exported through
+// (*Ring).FD.
+func newBacking(size uintptr) (buffer unsafe.Pointer, fd int, unmap func() error, err error) {
+	name := fmt.Sprintf("/mmu-ring-%d-%d", os.Getpid(), shmCounter.Add(1))
+
+	file, err := shmOpen(name, unix.O_CREAT|unix.O_EXCL|unix.O_RDWR, 0o600)
+	if err != nil {
+		return nil, -1, nil, fmt.Errorf("shm_open: %w", err)
+	}
+	defer shmUnlink(name) // best effort, the fd keeps the memory alive regardless
+	defer func() {
+		if err != nil {
+			unix.Close(file)
+		}
+	}()
+
+	if err := unix.Ftruncate(file, int64(size)); err != nil {
+		return nil, -1, nil, fmt.Errorf("ftruncate: %w", err)
+	}
+
+	buffer, unmap, err = attachBacking(file, size)
+	if err != nil {
+		return nil, -1, nil, err
+	}
+	return buffer, file, unmap, nil
+}
+
+// shmOpen and shmUnlink are not wrapped by golang.org/x/sys/unix, they are
+// plain syscalls on both Darwin and FreeBSD.
+func shmOpen(name string, flags int, mode uint32) (int, error) {
+	path, err := unix.BytePtrFromString(name)
+	if err != nil {
+		return -1, err
+	}
+	fd, _, errno := unix.Syscall(unix.SYS_SHM_OPEN, uintptr(unsafe.Pointer(path)), uintptr(flags), uintptr(mode))
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+func shmUnlink(name string) error {
+	path, err := unix.BytePtrFromString(name)
+	if err != nil {
+		return err
+	}
+	_, _, errno := unix.Syscall(unix.SYS_SHM_UNLINK, uintptr(unsafe.Pointer(path)), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
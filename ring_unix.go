@@ -0,0 +1,56 @@
+//go:build unix
+
+package ring
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// attachBacking double-maps a fd that is already sized, whether it was just
+// created by newBacking or received from another process via AttachFD.
+func attachBacking(file int, size uintptr) (buffer unsafe.Pointer, unmap func() error, err error) {
+	return attachBackingAt(file, 0, size)
+}
+
+// attachBackingAt is attachBacking generalized to double-map size bytes
+// starting at fileOffset instead of the start of file, so a single fd can
+// also carry out-of-band data ahead of the ring's own region (e.g.
+// NewFromFile's header page).
+//
+// The double mapping itself (reserve, then MAP_FIXED the same fd pages into
+// both halves) is identical across every unix newBacking implementation;
+// only the anonymous reservation's mmap flags vary per platform, via
+// anonMmapFlags.
+func attachBackingAt(file int, fileOffset int64, size uintptr) (buffer unsafe.Pointer, unmap func() error, err error) {
+	// temporary mapping to allocate twice the ring of virtual memory
+	orig, err := unix.MmapPtr(-1, 0, nil, size*2, unix.PROT_READ|unix.PROT_WRITE, anonMmapFlags)
+	if err != nil {
+		return nil, nil, fmt.Errorf("virtual mmap: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			unix.MunmapPtr(orig, size*2)
+		}
+	}()
+
+	// replace the virtual reservation with the physical memory tail to head
+	_, err = unix.MmapPtr(file, fileOffset, orig, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_FIXED)
+	if err != nil {
+		return nil, nil, fmt.Errorf("first physical mmap: %w", err)
+	}
+	_, err = unix.MmapPtr(file, fileOffset, unsafe.Add(orig, size), size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_FIXED)
+	if err != nil {
+		return nil, nil, fmt.Errorf("second physical mmap: %w", err)
+	}
+
+	return orig, func() error {
+		err := unix.MunmapPtr(orig, size*2)
+		if cerr := unix.Close(file); err == nil {
+			err = cerr
+		}
+		return err
+	}, nil
+}
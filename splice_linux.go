@@ -0,0 +1,280 @@
+//go:build linux
+
+package ring
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/sys/unix"
+)
+
+// maxSplicePipeSize mirrors the default fs.pipe-max-size used by the Go
+// runtime's own splice-based net.Conn plumbing (internal/poll/splice_linux.go):
+// large enough that one bounce pipe rarely needs more than a couple of
+// splice(2) calls to drain.
+const maxSplicePipeSize = 1 << 20
+
+// SpliceTo moves up to max bytes of buffered data directly from the ring's
+// backing memfd to dst (a pipe or socket fd) using splice(2), without
+// copying through userspace. It consumes the moved bytes the same way
+// Consume would. Content physically wraps past the end of the memfd at most
+// once, so this issues at most two splice transfers. If dst is a
+// non-blocking fd (as Go's runtime sets up for net.Conn) and applies
+// backpressure, SpliceTo blocks until it's ready rather than returning
+// early or erroring with EAGAIN.
+func (r *Ring) SpliceTo(dst int, max uintptr) (uintptr, error) {
+	n := r.contentLen
+	if max < n {
+		n = max
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	moved, err := spliceRegions(r.fd, r.tail, r.size, n, dst, true)
+	if cerr := r.Consume(moved); cerr != nil {
+		if err == nil {
+			err = cerr
+		}
+	}
+	return moved, err
+}
+
+// SpliceFrom moves up to max bytes of data directly from src (a pipe or
+// socket fd) into the ring's unused space using splice(2), without copying
+// through userspace. It advances the ring the same way Advance would. If src
+// is a non-blocking fd (as Go's runtime sets up for net.Conn) and has
+// nothing ready yet, SpliceFrom blocks until it does rather than returning 0
+// bytes moved, so a 0 return always means src actually reached EOF.
+func (r *Ring) SpliceFrom(src int, max uintptr) (uintptr, error) {
+	n := r.freeSpace()
+	if max < n {
+		n = max
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	head := (r.tail + r.contentLen) % r.size
+	moved, err := spliceRegions(r.fd, head, r.size, n, src, false)
+	if cerr := r.Advance(moved); cerr != nil {
+		if err == nil {
+			err = cerr
+		}
+	}
+	return moved, err
+}
+
+// spliceRegions moves up to n bytes between a [off, off+n) region of file
+// (wrapping at size, so it may be split into up to two physically
+// contiguous sub-regions) and external. fileToExternal selects the
+// direction: true moves file -> external (SpliceTo), false moves
+// external -> file (SpliceFrom). Backpressure on either side blocks (see
+// spliceViaPipe) rather than returning 0, but it stops and returns less than
+// n as soon as one transfer moves less than its sub-region: on EOF from
+// external, when external had fewer bytes ready than asked, when the bounce
+// pipe holds less than the sub-region (at most maxSplicePipeSize), or on an
+// error.
+func spliceRegions(file int, off, size, n uintptr, external int, fileToExternal bool) (uintptr, error) {
+	var moved uintptr
+	for moved < n {
+		chunk := size - ((off + moved) % size)
+		if remaining := n - moved; chunk > remaining {
+			chunk = remaining
+		}
+
+		fileOff := int64((off + moved) % size)
+		var m uintptr
+		var err error
+		if fileToExternal {
+			m, err = spliceViaPipe(file, &fileOff, external, nil, chunk)
+		} else {
+			m, err = spliceViaPipe(external, nil, file, &fileOff, chunk)
+		}
+		moved += m
+		if err != nil {
+			return moved, err
+		}
+		if m < chunk {
+			break
+		}
+	}
+	return moved, nil
+}
+
+// spliceViaPipe moves up to n bytes from in to out. splice(2) requires one
+// of its two file descriptors to be a pipe, and neither a memfd nor, in
+// general, the caller-provided dst/src is one, so the transfer is bounced
+// through a throwaway pipe: in -> pipe -> out.
+//
+// Both in and out are typically fds Go's runtime has already put in
+// non-blocking mode (e.g. a *net.TCPConn's fd), but splice(2) on them isn't
+// routed through the runtime's netpoller the way Read/Write are. So
+// spliceViaPipe does its own waiting: an EAGAIN from either leg blocks on
+// pollReady for that fd to become ready and retries, giving callers the same
+// blocking-until-ready behavior a normal Read/Write would have. It returns
+// (0, nil) only on genuine EOF from in, never to signal "try again".
+func spliceViaPipe(in int, inOff *int64, out int, outOff *int64, n uintptr) (uintptr, error) {
+	var fds [2]int
+	if err := unix.Pipe2(fds[:], unix.O_CLOEXEC); err != nil {
+		return 0, err
+	}
+	pr, pw := fds[0], fds[1]
+	defer unix.Close(pr)
+	defer unix.Close(pw)
+	unix.FcntlInt(uintptr(pw), unix.F_SETPIPE_SZ, maxSplicePipeSize) // best effort
+
+	want := n
+	if want > maxSplicePipeSize {
+		want = maxSplicePipeSize
+	}
+
+	var inPipe int
+	for {
+		var err error
+		inPipe, err = retrySplice(in, inOff, pw, nil, want)
+		if err == nil {
+			break
+		}
+		if err != unix.EAGAIN {
+			return 0, err
+		}
+		if perr := pollReady(in, unix.POLLIN); perr != nil {
+			return 0, perr
+		}
+	}
+	if inPipe == 0 {
+		return 0, nil // in is at EOF
+	}
+
+	var moved uintptr
+	for moved < uintptr(inPipe) {
+		pumped, err := retrySplice(pr, nil, out, outOff, uintptr(inPipe)-moved)
+		if err != nil {
+			if err == unix.EAGAIN {
+				if perr := pollReady(out, unix.POLLOUT); perr != nil {
+					return moved, perr
+				}
+				continue
+			}
+			return moved, err
+		}
+		moved += uintptr(pumped)
+	}
+	return moved, nil
+}
+
+// retrySplice calls splice(2), retrying on EINTR.
+func retrySplice(in int, inOff *int64, out int, outOff *int64, n uintptr) (int, error) {
+	for {
+		written, err := unix.Splice(in, inOff, out, outOff, int(n), unix.SPLICE_F_MOVE)
+		if err == unix.EINTR {
+			continue
+		}
+		return int(written), err
+	}
+}
+
+// pollReady blocks until fd is ready for events (unix.POLLIN or
+// unix.POLLOUT), so spliceViaPipe can wait on a non-blocking fd the same way
+// the runtime's netpoller would for a normal Read/Write.
+func pollReady(fd int, events int16) error {
+	pfd := []unix.PollFd{{Fd: int32(fd), Events: events}}
+	for {
+		_, err := unix.Poll(pfd, -1)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if pfd[0].Revents&(unix.POLLERR|unix.POLLNVAL) != 0 {
+			return fmt.Errorf("poll fd %d: revents %#x", fd, pfd[0].Revents)
+		}
+		return nil
+	}
+}
+
+// WriteTo writes the ring's currently buffered content to w, consuming it,
+// matching io.WriterTo's documented "until there's no more data to write or
+// an error occurs" as closely as a single buffered snapshot allows: unlike a
+// general io.WriterTo, WriteTo never observes more data than was already
+// buffered when it was called, since nothing refills the ring concurrently.
+//
+// If w exposes a raw file descriptor (e.g. *net.TCPConn, *os.File), the
+// transfer is done with SpliceTo to avoid copying through userspace, which
+// blocks through any backpressure from w rather than stopping early (see
+// SpliceTo), so the outer loop here only ever has to retry if SpliceTo
+// itself returned less than asked for (a transfer larger than one bounce
+// pipe holds, see spliceRegions).
+func (r *Ring) WriteTo(w io.Writer) (n int64, err error) {
+	if fd, ok := fdOf(w); ok {
+		for r.contentLen > 0 {
+			moved, err := r.SpliceTo(fd, r.contentLen)
+			n += int64(moved)
+			if err != nil || moved == 0 {
+				return n, err
+			}
+		}
+		return n, nil
+	}
+
+	data := r.Content()
+	if len(data) == 0 {
+		return 0, nil
+	}
+	written, err := w.Write(data)
+	if cerr := r.Consume(uintptr(written)); cerr != nil && err == nil {
+		err = cerr
+	}
+	return int64(written), err
+}
+
+// ReadFrom reads from src into the ring's unused space, advancing it. It
+// loops until the ring is full, src returns an error (io.EOF is not
+// reported, per io.ReaderFrom), or src reaches EOF, so a src that only
+// fills a partial Read (or splice) at a time is still drained as long as it
+// keeps making progress.
+//
+// If src exposes a raw file descriptor (e.g. *net.TCPConn, *os.File), the
+// transfer is done with SpliceFrom to avoid copying through userspace,
+// which blocks until src has more to give rather than returning early (see
+// SpliceFrom), so a 0 from SpliceFrom here always means src reached EOF.
+func (r *Ring) ReadFrom(src io.Reader) (n int64, err error) {
+	if fd, ok := fdOf(src); ok {
+		for r.freeSpace() > 0 {
+			moved, err := r.SpliceFrom(fd, r.freeSpace())
+			n += int64(moved)
+			if err != nil || moved == 0 {
+				return n, err
+			}
+		}
+		return n, nil
+	}
+
+	noProgress := 0
+	for r.freeSpace() > 0 {
+		buf := r.Unused()
+		read, rerr := src.Read(buf)
+		if read > 0 {
+			if aerr := r.Advance(uintptr(read)); aerr != nil {
+				return n, aerr
+			}
+			n += int64(read)
+			noProgress = 0
+		} else {
+			noProgress++
+			if noProgress > 100 {
+				return n, io.ErrNoProgress
+			}
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				rerr = nil
+			}
+			return n, rerr
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,249 @@
+//go:build linux
+
+package ring
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestRing_SpliceToFrom(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	left, right, err := unixSocketpair()
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	defer left.Close()
+	defer right.Close()
+
+	testData := bytes.Repeat([]byte("zero-copy via splice "), 100) // 2100 bytes
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	leftFD, ok := fdOf(left)
+	if !ok {
+		t.Fatalf("fdOf(left) failed")
+	}
+
+	moved, err := r.SpliceTo(leftFD, uintptr(len(testData)))
+	if err != nil {
+		t.Fatalf("SpliceTo failed: %v", err)
+	}
+	if moved != uintptr(len(testData)) {
+		t.Fatalf("SpliceTo moved %d bytes, want %d", moved, len(testData))
+	}
+
+	got := make([]byte, len(testData))
+	if _, err := readFull(right, got); err != nil {
+		t.Fatalf("read back failed: %v", err)
+	}
+	if !bytes.Equal(got, testData) {
+		t.Errorf("spliced data = %q, want %q", got, testData)
+	}
+
+	rightFD, ok := fdOf(right)
+	if !ok {
+		t.Fatalf("fdOf(right) failed")
+	}
+	if _, err := left.Write(testData); err != nil {
+		t.Fatalf("Write to socket failed: %v", err)
+	}
+	moved, err = r.SpliceFrom(rightFD, uintptr(len(testData)))
+	if err != nil {
+		t.Fatalf("SpliceFrom failed: %v", err)
+	}
+	if moved != uintptr(len(testData)) {
+		t.Fatalf("SpliceFrom moved %d bytes, want %d", moved, len(testData))
+	}
+	if !bytes.Equal(r.Content(), testData) {
+		t.Errorf("Content() after SpliceFrom = %q, want %q", r.Content(), testData)
+	}
+}
+
+// TestRing_SpliceToWraparound exercises the two-splice-calls path by
+// forcing the buffered content to wrap past the end of the memfd.
+func TestRing_SpliceToWraparound(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	left, right, err := unixSocketpair()
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	defer left.Close()
+	defer right.Close()
+
+	filler := bytes.Repeat([]byte{0xAA}, 3000)
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, filler)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := r.Consume(3000); err != nil {
+		t.Fatalf("Consume failed: %v", err)
+	}
+
+	testData := bytes.Repeat([]byte{0xBB}, 2000) // wraps: 4096-3000=1096 then 904
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	leftFD, ok := fdOf(left)
+	if !ok {
+		t.Fatalf("fdOf(left) failed")
+	}
+	moved, err := r.SpliceTo(leftFD, uintptr(len(testData)))
+	if err != nil {
+		t.Fatalf("SpliceTo failed: %v", err)
+	}
+	if moved != uintptr(len(testData)) {
+		t.Fatalf("SpliceTo moved %d bytes, want %d", moved, len(testData))
+	}
+
+	got := make([]byte, len(testData))
+	if _, err := readFull(right, got); err != nil {
+		t.Fatalf("read back failed: %v", err)
+	}
+	if !bytes.Equal(got, testData) {
+		t.Errorf("spliced wraparound data did not match")
+	}
+}
+
+func TestRing_WriteToReadFrom(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	left, right, err := unixSocketpair()
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	defer left.Close()
+	defer right.Close()
+
+	testData := []byte("routed through WriteTo/ReadFrom")
+	if _, err := r.Write(func(buffer []byte) (uintptr, error) {
+		return uintptr(copy(buffer, testData)), nil
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if _, err := r.WriteTo(left); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+
+	got := make([]byte, len(testData))
+	if _, err := readFull(right, got); err != nil {
+		t.Fatalf("read back failed: %v", err)
+	}
+	if !bytes.Equal(got, testData) {
+		t.Errorf("WriteTo data = %q, want %q", got, testData)
+	}
+
+	if _, err := left.Write(testData); err != nil {
+		t.Fatalf("Write to socket failed: %v", err)
+	}
+	// ReadFrom now blocks for genuine readiness (see SpliceFrom), so it only
+	// stops once the ring is full or right sees EOF; half-close left so
+	// right observes EOF right after the bytes written above.
+	if err := left.CloseWrite(); err != nil {
+		t.Fatalf("CloseWrite failed: %v", err)
+	}
+	if _, err := r.ReadFrom(right); err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if !bytes.Equal(r.Content(), testData) {
+		t.Errorf("Content() after ReadFrom = %q, want %q", r.Content(), testData)
+	}
+}
+
+// TestRing_ReadFromDrainsShortReads ensures ReadFrom keeps calling Read
+// across multiple short reads instead of stopping after the first one, so
+// it actually implements io.ReaderFrom's "until EOF or error" contract for
+// a non-fd source (see the loop added to fix this).
+func TestRing_ReadFromDrainsShortReads(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	testData := bytes.Repeat([]byte("x"), 10)
+	n, err := r.ReadFrom(iotest.OneByteReader(bytes.NewReader(testData)))
+	if err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if n != int64(len(testData)) {
+		t.Fatalf("ReadFrom returned n = %d, want %d", n, len(testData))
+	}
+	if !bytes.Equal(r.Content(), testData) {
+		t.Errorf("Content() after ReadFrom = %q, want %q", r.Content(), testData)
+	}
+}
+
+// TestRing_SpliceFromWaitsForReadiness ensures SpliceFrom blocks until src
+// actually has data ready instead of the non-blocking fd underneath it
+// returning EAGAIN straight back as a spurious 0-bytes-moved "success" (the
+// bug this test guards against: see the pollReady wait added to fix it).
+func TestRing_SpliceFromWaitsForReadiness(t *testing.T) {
+	r, err := New(4096)
+	if err != nil {
+		t.Fatalf("Failed to create Ring: %v", err)
+	}
+	defer r.Close()
+
+	left, right, err := unixSocketpair()
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	defer left.Close()
+	defer right.Close()
+
+	testData := []byte("delayed write")
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		left.Write(testData)
+	}()
+
+	rightFD, ok := fdOf(right)
+	if !ok {
+		t.Fatalf("fdOf(right) failed")
+	}
+	moved, err := r.SpliceFrom(rightFD, uintptr(len(testData)))
+	if err != nil {
+		t.Fatalf("SpliceFrom failed: %v", err)
+	}
+	if moved != uintptr(len(testData)) {
+		t.Fatalf("SpliceFrom moved %d bytes, want %d (it must block for the delayed write instead of reporting EAGAIN as done)", moved, len(testData))
+	}
+}
+
+func readFull(conn *net.UnixConn, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := conn.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,141 @@
+package ring
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+// SPSC is the same double-mapped ring as Ring, but head and tail are updated
+// with atomics instead of being owned by a single caller, so one goroutine
+// can produce into it while a different goroutine consumes from it
+// concurrently, without a mutex. It must only ever have one producer
+// goroutine calling Unused/Advance/Write and one consumer goroutine calling
+// Content/Consume/Read; neither side is safe to call from more than one
+// goroutine at a time.
+//
+// head and tail are monotonic and never taken mod size except when indexing
+// into buffer, so the producer and consumer can tell "full" and "empty"
+// apart without sacrificing a slot.
+type SPSC struct {
+	size   uintptr
+	buffer *byte // manually managed memory
+	unmap  func() error
+	head   atomic.Uint64 // owned by the producer, read by the consumer
+	tail   atomic.Uint64 // owned by the consumer, read by the producer
+}
+
+func NewSPSC(size uintptr) (*SPSC, error) {
+	// outlined so this can be inlined and &SPSC{} heap allocation ellided
+	r := &SPSC{}
+	if err := r.Init(size); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Init initializes the ring buffer with the given size.
+func (r *SPSC) Init(size uintptr) (err error) {
+	if r.buffer != nil {
+		return fmt.Errorf("ring already initialized")
+	}
+
+	if err := validateSize(size); err != nil {
+		return err
+	}
+
+	buffer, _, unmap, err := newBacking(size)
+	if err != nil {
+		return err
+	}
+
+	*r = SPSC{size: size, buffer: (*byte)(buffer), unmap: unmap}
+	return nil
+}
+
+func (r *SPSC) Close() error {
+	if r.buffer == nil {
+		return nil
+	}
+	err := r.unmap()
+	r.buffer = nil
+	r.unmap = nil
+	return err
+}
+
+func (r *SPSC) Size() uintptr {
+	return r.size
+}
+
+// Unused returns a single contiguous slice of unused memory, for the
+// producer goroutine only. The returned slice is only valid until the next
+// call to Advance.
+func (r *SPSC) Unused() []byte {
+	tail := r.tail.Load() // acquire: see everything the consumer has freed
+	head := r.head.Load() // our own monotonic position
+	i := head % uint64(r.size)
+	free := uintptr(r.size) - uintptr(head-tail)
+	return unsafe.Slice((*byte)(unsafe.Add(unsafe.Pointer(r.buffer), i)), int(free))
+}
+
+// Advance bumps head, in other words it tells the ring that the producer
+// has written to the slice returned by Unused and makes that data visible
+// to the consumer.
+func (r *SPSC) Advance(n uintptr) error {
+	tail := r.tail.Load()
+	head := r.head.Load()
+	if n > uintptr(r.size)-uintptr(head-tail) {
+		return fmt.Errorf("not enough space in ring")
+	}
+
+	r.head.Store(head + uint64(n)) // release: publish the new data to the consumer
+	return nil
+}
+
+// Write is an alternative to Unused and Advance, you get called back with a reference to the unused buffer and return how many new bytes are there.
+func (r *SPSC) Write(f func(buffer []byte) (newData uintptr, err error)) (newData uintptr, err error) {
+	newData, err = f(r.Unused())
+	if err != nil {
+		return 0, err
+	}
+	err = r.Advance(newData)
+	if err != nil {
+		return 0, err
+	}
+	return newData, nil
+}
+
+// Content returns a single contiguous slice of buffered data, for the
+// consumer goroutine only. The returned slice is only valid until the next
+// call to Consume.
+func (r *SPSC) Content() []byte {
+	head := r.head.Load() // acquire: see everything the producer has published
+	tail := r.tail.Load() // our own monotonic position
+	i := tail % uint64(r.size)
+	return unsafe.Slice((*byte)(unsafe.Add(unsafe.Pointer(r.buffer), i)), int(head-tail))
+}
+
+// Consume bumps tail, freeing the space back up for the producer to reuse.
+func (r *SPSC) Consume(n uintptr) error {
+	head := r.head.Load()
+	tail := r.tail.Load()
+	if uint64(n) > head-tail {
+		return fmt.Errorf("not enough data in ring")
+	}
+
+	r.tail.Store(tail + uint64(n)) // release: tell the producer this space is free again
+	return nil
+}
+
+// Read is an alternative to Content and Consume, you get called back with a reference to the used buffer and return how many bytes you have consumed.
+func (r *SPSC) Read(f func(buffer []byte) (consumed uintptr, err error)) (consumed uintptr, err error) {
+	consumed, err = f(r.Content())
+	if err != nil {
+		return 0, err
+	}
+	err = r.Consume(consumed)
+	if err != nil {
+		return 0, err
+	}
+	return consumed, nil
+}